@@ -0,0 +1,321 @@
+package eth
+
+import (
+    "context"
+    "fmt"
+    "reflect"
+
+    "github.com/PaloAltoNetworks/xapi/netw/eth/layer2subinterface"
+    "github.com/PaloAltoNetworks/xapi/netw/eth/subinterface"
+)
+
+// ApplyOptions controls how Eth.Apply reconciles the desired interface set.
+type ApplyOptions struct {
+    // DryRun computes the diff without changing anything on the firewall.
+    DryRun bool
+
+    // Prune deletes interfaces under the ethernet xpath that aren't present
+    // in the desired set.
+    Prune bool
+
+    // ContinueOnError applies as much of the diff as it can instead of
+    // rolling back to the pre-Apply snapshot on the first error.
+    ContinueOnError bool
+}
+
+// ApplyResult describes what Eth.Apply changed (or, for a dry run, would
+// change). When Apply returns an error with ContinueOnError set, or because
+// ctx was cancelled after the diff was computed but before it was applied,
+// Created, Updated and Deleted still list every step of the computed diff,
+// whether or not it was carried out; Errors holds the steps that failed
+// under ContinueOnError.
+type ApplyResult struct {
+    Created []string
+    Updated []string
+    Deleted []string
+
+    // Errors holds the failure for each create/update/delete step that
+    // failed when ApplyOptions.ContinueOnError is set, keyed by
+    // "<step>:<name>" (e.g. "create:ethernet1/1", "delete:ethernet1/2").
+    Errors map[string]error
+}
+
+// Apply reconciles the firewall's ethernet interfaces to match desired.
+//
+// It snapshots the current interfaces (including their layer2/layer3
+// subinterfaces), computes a minimal create / update / delete diff against
+// desired, then applies that diff as an ordinary sequence of Set /
+// DeleteCascade calls, one interface at a time. This is not a PAN-OS
+// candidate-config transaction: if a step fails and ContinueOnError isn't
+// set, Apply attempts to restore the prior config by replaying the snapshot
+// through that same kind of ordinary calls, but that restore is itself a
+// best-effort, non-atomic sequence and can fail partway through (in which
+// case the returned error reports both failures and the firewall is left in
+// whatever partially-applied or partially-restored state the last
+// successful call produced). The vsys import/unimport for created, updated,
+// and deleted interfaces is performed as part of the same calls.
+func (c *Eth) Apply(ctx context.Context, vsys string, desired []Entry, opts ApplyOptions) (ApplyResult, error) {
+    var result ApplyResult
+
+    if err := ctx.Err(); err != nil {
+        return result, err
+    }
+
+    existingNames, err := c.GetList()
+    if err != nil {
+        return result, err
+    }
+
+    snapshot, err := c.snapshotAll(ctx, existingNames)
+    if err != nil {
+        return result, err
+    }
+
+    toCreate, toUpdate, toDelete := computeDiff(desired, snapshot, opts.Prune)
+
+    result.Created = namesOf(toCreate)
+    result.Updated = namesOf(toUpdate)
+    result.Deleted = toDelete
+
+    if opts.DryRun {
+        return result, nil
+    }
+
+    if err := ctx.Err(); err != nil {
+        return result, err
+    }
+
+    errs, applyErr := c.applyDiff(ctx, vsys, toCreate, toUpdate, toDelete, opts.ContinueOnError)
+    if applyErr == nil {
+        return result, nil
+    }
+
+    if opts.ContinueOnError {
+        result.Errors = errs
+        return result, applyErr
+    }
+
+    if rbErr := c.revert(vsys, snapshot); rbErr != nil {
+        return result, fmt.Errorf("apply failed (%v), and rollback to the prior snapshot also failed (%v)", applyErr, rbErr)
+    }
+
+    return ApplyResult{}, applyErr
+}
+
+// computeDiff compares desired against snapshot and returns the entries to
+// create, the entries to update, and the names to delete (the latter only
+// when prune is set). It's a pure function so the diff logic, including the
+// raw-preservation behavior described below, can be tested without a live
+// client.
+func computeDiff(desired []Entry, snapshot map[string]ifaceSnapshot, prune bool) (toCreate, toUpdate []Entry, toDelete []string) {
+    desiredByName := make(map[string]Entry, len(desired))
+    for _, e := range desired {
+        desiredByName[e.Name] = e
+    }
+
+    for name, e := range desiredByName {
+        if old, ok := snapshot[name]; ok {
+            // Entries round-tripped through Get carry whatever raw XML PAN-OS
+            // returned for fields this package doesn't model yet. Callers
+            // can never populate that unexported raw fallback themselves, so
+            // carry the old value forward onto the desired entry before
+            // diffing/specifying it: otherwise every reconciliation pass
+            // would see a spurious diff and then Set() an entry with those
+            // elements omitted, wiping them from the live config.
+            e.raw = old.Entry.raw
+            if !reflect.DeepEqual(old.Entry, e) {
+                toUpdate = append(toUpdate, e)
+            }
+        } else {
+            toCreate = append(toCreate, e)
+        }
+    }
+
+    if prune {
+        for name := range snapshot {
+            if _, ok := desiredByName[name]; !ok {
+                toDelete = append(toDelete, name)
+            }
+        }
+    }
+
+    return toCreate, toUpdate, toDelete
+}
+
+// applyDiff applies toDelete, then toCreate and toUpdate, one interface at a
+// time so that a failure can be attributed to the specific create/update/
+// delete step (and entry) that failed instead of the diff as a whole. It
+// stops at the first failure unless continueOnError is set, in which case it
+// keeps going and returns every failure it saw.
+func (c *Eth) applyDiff(ctx context.Context, vsys string, toCreate, toUpdate []Entry, toDelete []string, continueOnError bool) (map[string]error, error) {
+    errs := make(map[string]error)
+
+    for _, name := range toDelete {
+        if err := ctx.Err(); err != nil {
+            return errs, err
+        }
+        if err := c.DeleteCascade(vsys, name); err != nil {
+            errs["delete:"+name] = err
+            if !continueOnError {
+                return errs, err
+            }
+        }
+    }
+
+    creating := make(map[string]bool, len(toCreate))
+    for _, e := range toCreate {
+        creating[e.Name] = true
+    }
+
+    for _, e := range append(append([]Entry{}, toCreate...), toUpdate...) {
+        if err := ctx.Err(); err != nil {
+            return errs, err
+        }
+        if err := c.Set(vsys, e); err != nil {
+            step := "update"
+            if creating[e.Name] {
+                step = "create"
+            }
+            errs[step+":"+e.Name] = err
+            if !continueOnError {
+                return errs, err
+            }
+        }
+    }
+
+    if len(errs) > 0 {
+        return errs, fmt.Errorf("apply failed for %d step(s)", len(errs))
+    }
+
+    return errs, nil
+}
+
+// ifaceSnapshot is everything Apply needs to restore a single interface:
+// the interface itself plus whatever layer2/layer3 subinterfaces (units)
+// were configured underneath it. Without these, reverting an interface that
+// DeleteCascade removed (e.g. via Prune) would recreate the bare interface
+// but leave its units gone for good.
+type ifaceSnapshot struct {
+    Entry Entry
+    Layer3Units []subinterface.Entry
+    Layer2Units []layer2subinterface.Entry
+}
+
+// snapshotAll captures the current config of each named interface,
+// including its layer2/layer3 subinterfaces, for later use by revert.
+func (c *Eth) snapshotAll(ctx context.Context, names []string) (map[string]ifaceSnapshot, error) {
+    snapshot := make(map[string]ifaceSnapshot, len(names))
+
+    for _, name := range names {
+        if err := ctx.Err(); err != nil {
+            return nil, err
+        }
+
+        e, err := c.Get(name)
+        if err != nil {
+            return nil, err
+        }
+        s := ifaceSnapshot{Entry: e}
+
+        switch e.Mode {
+        case "layer3":
+            units, err := c.Layer3Subinterfaces(name).GetList()
+            if err != nil {
+                return nil, err
+            }
+            for _, unit := range units {
+                if err := ctx.Err(); err != nil {
+                    return nil, err
+                }
+                ue, err := c.Layer3Subinterfaces(name).Get(unit)
+                if err != nil {
+                    return nil, err
+                }
+                s.Layer3Units = append(s.Layer3Units, ue)
+            }
+        case "layer2":
+            units, err := c.Layer2Subinterfaces(name).GetList()
+            if err != nil {
+                return nil, err
+            }
+            for _, unit := range units {
+                if err := ctx.Err(); err != nil {
+                    return nil, err
+                }
+                ue, err := c.Layer2Subinterfaces(name).Get(unit)
+                if err != nil {
+                    return nil, err
+                }
+                s.Layer2Units = append(s.Layer2Units, ue)
+            }
+        }
+
+        snapshot[name] = s
+    }
+
+    return snapshot, nil
+}
+
+// revert restores the firewall's interfaces, and their layer2/layer3
+// subinterfaces, to match snapshot, deleting anything that Apply managed to
+// create before it failed. Like Apply itself, this is a best-effort
+// sequence of ordinary calls, not an atomic operation: it can fail partway
+// through, in which case the caller's error will report that alongside the
+// failure that triggered the revert.
+func (c *Eth) revert(vsys string, snapshot map[string]ifaceSnapshot) error {
+    entries := make([]Entry, 0, len(snapshot))
+    for _, s := range snapshot {
+        entries = append(entries, s.Entry)
+    }
+    if len(entries) > 0 {
+        if err := c.Set(vsys, entries...); err != nil {
+            return err
+        }
+    }
+
+    for name, s := range snapshot {
+        if len(s.Layer3Units) > 0 {
+            if err := c.Layer3Subinterfaces(name).Set(s.Layer3Units...); err != nil {
+                return err
+            }
+        }
+        if len(s.Layer2Units) > 0 {
+            if err := c.Layer2Subinterfaces(name).Set(s.Layer2Units...); err != nil {
+                return err
+            }
+        }
+    }
+
+    current, err := c.GetList()
+    if err != nil {
+        return err
+    }
+
+    var extra []string
+    for _, name := range current {
+        if _, ok := snapshot[name]; !ok {
+            extra = append(extra, name)
+        }
+    }
+    if len(extra) == 0 {
+        return nil
+    }
+
+    return c.DeleteCascade(vsys, stringsToInterfaces(extra)...)
+}
+
+func namesOf(entries []Entry) []string {
+    out := make([]string, len(entries))
+    for i := range entries {
+        out[i] = entries[i].Name
+    }
+    return out
+}
+
+func stringsToInterfaces(vals []string) []interface{} {
+    out := make([]interface{}, len(vals))
+    for i := range vals {
+        out[i] = vals[i]
+    }
+    return out
+}
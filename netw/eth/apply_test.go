@@ -0,0 +1,115 @@
+package eth
+
+import (
+    "reflect"
+    "sort"
+    "testing"
+)
+
+func TestComputeDiff(t *testing.T) {
+    testCases := []struct {
+        name string
+        desired []Entry
+        snapshot map[string]ifaceSnapshot
+        prune bool
+        wantCreate []string
+        wantUpdate []string
+        wantDelete []string
+    }{
+        {
+            name: "new interface is created",
+            desired: []Entry{{Name: "ethernet1/1", Mtu: 1500}},
+            snapshot: map[string]ifaceSnapshot{},
+            wantCreate: []string{"ethernet1/1"},
+        },
+        {
+            name: "identical interface is left alone",
+            desired: []Entry{{Name: "ethernet1/1", Mtu: 1500}},
+            snapshot: map[string]ifaceSnapshot{
+                "ethernet1/1": {Entry: Entry{Name: "ethernet1/1", Mtu: 1500}},
+            },
+        },
+        {
+            name: "changed interface is updated",
+            desired: []Entry{{Name: "ethernet1/1", Mtu: 9000}},
+            snapshot: map[string]ifaceSnapshot{
+                "ethernet1/1": {Entry: Entry{Name: "ethernet1/1", Mtu: 1500}},
+            },
+            wantUpdate: []string{"ethernet1/1"},
+        },
+        {
+            name: "unpruned interface missing from desired is left alone",
+            desired: nil,
+            snapshot: map[string]ifaceSnapshot{
+                "ethernet1/1": {Entry: Entry{Name: "ethernet1/1"}},
+            },
+            prune: false,
+        },
+        {
+            name: "pruned interface missing from desired is deleted",
+            desired: nil,
+            snapshot: map[string]ifaceSnapshot{
+                "ethernet1/1": {Entry: Entry{Name: "ethernet1/1"}},
+            },
+            prune: true,
+            wantDelete: []string{"ethernet1/1"},
+        },
+        {
+            name: "raw fallback carried over from snapshot doesn't force an update",
+            desired: []Entry{{Name: "ethernet1/1", Mtu: 1500}},
+            snapshot: map[string]ifaceSnapshot{
+                "ethernet1/1": {Entry: Entry{Name: "ethernet1/1", Mtu: 1500, raw: map[string]string{"arp": "<entry/>"}}},
+            },
+        },
+        {
+            name: "a real change still updates when a raw fallback is present",
+            desired: []Entry{{Name: "ethernet1/1", Mtu: 9000}},
+            snapshot: map[string]ifaceSnapshot{
+                "ethernet1/1": {Entry: Entry{Name: "ethernet1/1", Mtu: 1500, raw: map[string]string{"arp": "<entry/>"}}},
+            },
+            wantUpdate: []string{"ethernet1/1"},
+        },
+    }
+
+    for _, tc := range testCases {
+        t.Run(tc.name, func(t *testing.T) {
+            toCreate, toUpdate, toDelete := computeDiff(tc.desired, tc.snapshot, tc.prune)
+
+            if got := namesOf(toCreate); !sameNames(got, tc.wantCreate) {
+                t.Errorf("toCreate = %v, want %v", got, tc.wantCreate)
+            }
+            if got := namesOf(toUpdate); !sameNames(got, tc.wantUpdate) {
+                t.Errorf("toUpdate = %v, want %v", got, tc.wantUpdate)
+            }
+            if !sameNames(toDelete, tc.wantDelete) {
+                t.Errorf("toDelete = %v, want %v", toDelete, tc.wantDelete)
+            }
+        })
+    }
+}
+
+func TestComputeDiffPreservesRawOntoUpdatedEntry(t *testing.T) {
+    snapshot := map[string]ifaceSnapshot{
+        "ethernet1/1": {Entry: Entry{Name: "ethernet1/1", Mtu: 1500, raw: map[string]string{"arp": "<entry/>"}}},
+    }
+    desired := []Entry{{Name: "ethernet1/1", Mtu: 9000}}
+
+    _, toUpdate, _ := computeDiff(desired, snapshot, false)
+    if len(toUpdate) != 1 {
+        t.Fatalf("got %d updates, want 1", len(toUpdate))
+    }
+    if toUpdate[0].raw["arp"] != "<entry/>" {
+        t.Errorf("update entry lost the raw arp fallback: %#v", toUpdate[0].raw)
+    }
+}
+
+func sameNames(got, want []string) bool {
+    if len(got) == 0 && len(want) == 0 {
+        return true
+    }
+    g := append([]string{}, got...)
+    w := append([]string{}, want...)
+    sort.Strings(g)
+    sort.Strings(w)
+    return reflect.DeepEqual(g, w)
+}
@@ -7,8 +7,9 @@ import (
     "fmt"
     "encoding/xml"
 
+    "github.com/PaloAltoNetworks/xapi/netw/eth/layer2subinterface"
+    "github.com/PaloAltoNetworks/xapi/netw/eth/subinterface"
     "github.com/PaloAltoNetworks/xapi/util"
-    "github.com/PaloAltoNetworks/xapi/version"
 )
 
 
@@ -22,16 +23,23 @@ type Entry struct {
     CreateDhcpDefaultRoute bool
     DhcpDefaultRouteMetric int
     Ipv6Enabled bool
+    Ipv6InterfaceId string
+    Ipv6Addresses []Ipv6Address
+    NeighborDiscovery *NeighborDiscovery
+    Dhcpv6Client *Dhcpv6Client
     ManagementProfile string
     Mtu int
     AdjustTcpMss bool
     NetflowProfile string
     LldpEnabled bool
     LldpProfile string
+    LldpHighAvailability bool
+    LldpPassivePreNegotiation bool
     LinkSpeed string
     LinkDuplex string
     LinkState string
     AggregateGroup string
+    Lacp *Lacp
     Comment string
     Ipv4MssAdjust int
     Ipv6MssAdjust int
@@ -39,6 +47,66 @@ type Entry struct {
     raw map[string] string
 }
 
+// Lacp is the LACP configuration for an interface participating in an
+// aggregate group.
+type Lacp struct {
+    Enable bool
+    Mode string
+    TransmissionRate string
+    SystemPriority int
+    MaxPorts int
+    FastFailover bool
+    PortPriority int
+}
+
+// Ipv6Address is a single IPv6 address / prefix assigned to a layer3
+// interface.
+type Ipv6Address struct {
+    Name string
+    EnableOnInterface bool
+    Prefix bool
+    Anycast bool
+    AdvertiseEnabled bool
+    ValidLifetime int
+    PreferredLifetime int
+    OnlinkFlag bool
+    AutonomousFlag bool
+}
+
+// NeighborDiscovery is the IPv6 neighbor discovery / router advertisement
+// configuration for a layer3 interface.
+type NeighborDiscovery struct {
+    DadAttempts int
+    NsInterval int
+    ReachableTime int
+    RaEnabled bool
+    RaMaxInterval int
+    RaMinInterval int
+    RaManagedFlag bool
+    RaOtherFlag bool
+    RaLifetime int
+    RaHopLimit int
+    RaLinkMtu string
+    RaRouterPreference string
+    RaDnsSupportEnabled bool
+    RdnssServers []RdnssServer
+    DnsSearchList []string
+}
+
+// RdnssServer is a single recursive DNS server advertised via router
+// advertisements.
+type RdnssServer struct {
+    Server string
+    Lifetime int
+}
+
+// Dhcpv6Client is the DHCPv6 client configuration for a layer3 interface.
+type Dhcpv6Client struct {
+    Enable bool
+    PrefixDelegationEnabled bool
+    RapidCommit bool
+}
+
 // Eth is the client.Network.EthernetInterface namespace.
 type Eth struct {
     con util.XapiClient
@@ -49,6 +117,26 @@ func (c *Eth) Initialize(con util.XapiClient) {
     c.con = con
 }
 
+// Layer3Subinterfaces returns the subinterface namespace for the tagged
+// VLAN units of the given parent ethernet interface.
+//
+// The raw["l3subinterface"] fallback on Entry remains available for callers
+// that have not yet migrated to this namespace; this is the recommended API
+// going forward.
+func (c *Eth) Layer3Subinterfaces(eth string) *subinterface.Subinterface {
+    return subinterface.New(c.con, eth)
+}
+
+// Layer2Subinterfaces returns the subinterface namespace for the VLAN units
+// of the given parent ethernet interface while it's in layer2 mode.
+//
+// The raw["l2subinterface"] fallback on Entry remains available for callers
+// that have not yet migrated to this namespace; this is the recommended API
+// going forward.
+func (c *Eth) Layer2Subinterfaces(eth string) *layer2subinterface.Subinterface {
+    return layer2subinterface.New(c.con, eth)
+}
+
 // ShowList performs SHOW to retrieve a list of ethernet interfaces.
 func (c *Eth) ShowList() ([]string, error) {
     c.con.LogQuery("(show) list of ethernet interfaces")
@@ -86,13 +174,17 @@ func (c *Eth) Set(vsys string, e ...Entry) error {
         return nil
     }
 
-    _, fn := c.versioning()
+    drv := c.versioning()
     names := make([]string, len(e))
 
     // Build up the struct with the given interface configs.
     d := util.BulkElement{XMLName: xml.Name{Local: "ethernet"}}
     for i := range e {
-        d.Data = append(d.Data, fn(e[i]))
+        spec, err := drv.Specify(e[i])
+        if err != nil {
+            return err
+        }
+        d.Data = append(d.Data, spec)
         names[i] = e[i].Name
     }
     c.con.LogAction("(set) ethernet interfaces: %v", names)
@@ -122,7 +214,11 @@ func (c *Eth) Set(vsys string, e ...Entry) error {
 func (c *Eth) Edit(vsys string, e Entry) error {
     var err error
 
-    _, fn := c.versioning()
+    drv := c.versioning()
+    spec, err := drv.Specify(e)
+    if err != nil {
+        return err
+    }
 
     c.con.LogAction("(edit) ethernet interface: %v", e.Name)
 
@@ -130,7 +226,7 @@ func (c *Eth) Edit(vsys string, e Entry) error {
     path := c.xpath([]string{e.Name})
 
     // Edit the interface.
-    _, err = c.con.Edit(path, fn(e), nil, nil)
+    _, err = c.con.Edit(path, spec, nil, nil)
     if err != nil {
         return err
     }
@@ -145,7 +241,26 @@ func (c *Eth) Edit(vsys string, e Entry) error {
 // the vsys prior to deleting them.
 //
 // Interfaces can be a string or an eth.Entry object.
+//
+// This does not remove any layer2 or layer3 subinterfaces (units) still
+// configured underneath the interface(s); use DeleteCascade for that.
 func (c *Eth) Delete(vsys string, e ...interface{}) error {
+    return c.delete(vsys, false, e...)
+}
+
+// DeleteCascade removes the given interface(s) from the firewall, first
+// deleting any layer2 or layer3 subinterfaces (units) configured underneath
+// them.
+//
+// Specify a non-empty vsys to have this function remove the interface(s) from
+// the vsys prior to deleting them.
+//
+// Interfaces can be a string or an eth.Entry object.
+func (c *Eth) DeleteCascade(vsys string, e ...interface{}) error {
+    return c.delete(vsys, true, e...)
+}
+
+func (c *Eth) delete(vsys string, cascade bool, e ...interface{}) error {
     var err error
 
     if len(e) == 0 {
@@ -165,6 +280,30 @@ func (c *Eth) Delete(vsys string, e ...interface{}) error {
     }
     c.con.LogAction("(delete) ethernet interface(s): %v", names)
 
+    if cascade {
+        for _, name := range names {
+            units, err := c.Layer3Subinterfaces(name).GetList()
+            if err != nil {
+                return err
+            }
+            if len(units) > 0 {
+                if err = c.Layer3Subinterfaces(name).Delete(stringsToInterfaces(units)...); err != nil {
+                    return err
+                }
+            }
+
+            l2units, err := c.Layer2Subinterfaces(name).GetList()
+            if err != nil {
+                return err
+            }
+            if len(l2units) > 0 {
+                if err = c.Layer2Subinterfaces(name).Delete(stringsToInterfaces(l2units)...); err != nil {
+                    return err
+                }
+            }
+        }
+    }
+
     // Unimport interfaces from the given vsys.
     err = c.con.UnimportInterfaces(vsys, names)
     if err != nil {
@@ -179,25 +318,18 @@ func (c *Eth) Delete(vsys string, e ...interface{}) error {
 
 /** Internal functions for the Eth struct **/
 
-func (c *Eth) versioning() (normalizer, func(Entry) (interface{})) {
-    v := c.con.Versioning()
-
-    if v.Gte(version.Number{7, 1, 0, ""}) {
-        return &container_v2{}, specify_v2
-    } else {
-        return &container_v1{}, specify_v1
-    }
+func (c *Eth) versioning() Driver {
+    return bestDriver(c.con.Versioning())
 }
 
 func (c *Eth) details(fn func(interface{}, interface{}, interface{}) ([]byte, error), name string) (Entry, error) {
     path := c.xpath([]string{name})
-    obj, _ := c.versioning()
-    if _, err := fn(path, nil, obj); err != nil {
+    raw, err := fn(path, nil, nil)
+    if err != nil {
         return Entry{}, err
     }
-    ans := obj.Normalize()
 
-    return ans, nil
+    return c.versioning().Normalize(raw)
 }
 
 func (c *Eth) xpath(vals []string) []string {
@@ -214,10 +346,6 @@ func (c *Eth) xpath(vals []string) []string {
 
 /** Structs / functions for this namespace. **/
 
-type normalizer interface {
-    Normalize() Entry
-}
-
 type container_v1 struct {
     Answer entry_v1 `xml:"result>entry"`
 }
@@ -257,6 +385,8 @@ func (o *container_v1) Normalize() Entry {
             ans.Mode = "layer2"
             ans.LldpEnabled = util.AsBool(o.Answer.ModeL2.LldpEnabled)
             ans.LldpProfile = o.Answer.ModeL2.LldpProfile
+            ans.LldpHighAvailability = util.AsBool(o.Answer.ModeL2.LldpHighAvailability)
+            ans.LldpPassivePreNegotiation = util.AsBool(o.Answer.ModeL2.LldpPassivePreNegotiation)
             ans.NetflowProfile = o.Answer.ModeL2.NetflowProfile
             if o.Answer.ModeL2.Subinterface != nil {
                 ans.raw["l2subinterface"] = util.CleanRawXml(o.Answer.ModeL2.Subinterface.Text)
@@ -265,6 +395,8 @@ func (o *container_v1) Normalize() Entry {
             ans.Mode = "virtual-wire"
             ans.LldpEnabled = util.AsBool(o.Answer.ModeVwire.LldpEnabled)
             ans.LldpProfile = o.Answer.ModeVwire.LldpProfile
+            ans.LldpHighAvailability = util.AsBool(o.Answer.ModeVwire.LldpHighAvailability)
+            ans.LldpPassivePreNegotiation = util.AsBool(o.Answer.ModeVwire.LldpPassivePreNegotiation)
             ans.NetflowProfile = o.Answer.ModeVwire.NetflowProfile
         case o.Answer.TapMode != nil:
             ans.Mode = "tap"
@@ -274,6 +406,18 @@ func (o *container_v1) Normalize() Entry {
             ans.Mode = "decrypt-mirror"
         case o.Answer.AggregateGroupMode != nil:
             ans.Mode = "aggregate-group"
+            if o.Answer.AggregateGroupMode.Lacp != nil {
+                l := o.Answer.AggregateGroupMode.Lacp
+                ans.Lacp = &Lacp{
+                    Enable: util.AsBool(l.Enable),
+                    Mode: l.Mode,
+                    TransmissionRate: l.TransmissionRate,
+                    SystemPriority: l.SystemPriority,
+                    MaxPorts: l.MaxPorts,
+                    FastFailover: util.AsBool(l.FastFailover),
+                    PortPriority: l.PortPriority,
+                }
+            }
     }
 
     return ans
@@ -288,7 +432,7 @@ type entry_v1 struct {
     TapMode *emptyMode `xml:"tap"`
     HaMode *emptyMode `xml:"ha"`
     DecryptMirrorMode *emptyMode `xml:"decrypt-mirror"`
-    AggregateGroupMode *emptyMode `xml:"aggregate-group"`
+    AggregateGroupMode *aggMode `xml:"aggregate-group"`
     LinkSpeed string `xml:"link-speed,omitempty"`
     LinkDuplex string `xml:"link-duplex,omitempty"`
     LinkState string `xml:"link-state,omitempty"`
@@ -297,9 +441,25 @@ type entry_v1 struct {
 
 type emptyMode struct {}
 
+type aggMode struct {
+    Lacp *lacp `xml:"lacp"`
+}
+
+type lacp struct {
+    Enable string `xml:"enable"`
+    Mode string `xml:"mode,omitempty"`
+    TransmissionRate string `xml:"transmission-rate,omitempty"`
+    SystemPriority int `xml:"system-priority,omitempty"`
+    MaxPorts int `xml:"max-ports,omitempty"`
+    FastFailover string `xml:"fast-failover"`
+    PortPriority int `xml:"port-priority,omitempty"`
+}
+
 type otherMode struct {
     LldpEnabled string `xml:"lldp>enable"`
     LldpProfile string `xml:"lldp>profile"`
+    LldpHighAvailability string `xml:"lldp>high-availability>enabled"`
+    LldpPassivePreNegotiation string `xml:"lldp>high-availability>passive-pre-negotiation"`
     NetflowProfile string `xml:"netflow-profile,omitempty"`
     Subinterface *util.RawXml `xml:"units"`
 }
@@ -344,6 +504,7 @@ func (o *container_v2) Normalize() Entry {
         case o.Answer.ModeL3 != nil:
             ans.Mode = "layer3"
             ans.Ipv6Enabled = util.AsBool(o.Answer.ModeL3.Ipv6.Enabled)
+            ans.Ipv6InterfaceId = o.Answer.ModeL3.Ipv6.InterfaceId
             ans.ManagementProfile = o.Answer.ModeL3.ManagementProfile
             ans.Mtu = o.Answer.ModeL3.Mtu
             ans.NetflowProfile = o.Answer.ModeL3.NetflowProfile
@@ -362,13 +523,73 @@ func (o *container_v2) Normalize() Entry {
             if o.Answer.ModeL3.Subinterface != nil {
                 ans.raw["l3subinterface"] = util.CleanRawXml(o.Answer.ModeL3.Subinterface.Text)
             }
-            if o.Answer.ModeL3.Ipv6.Address != nil {
-                ans.raw["ipv6"] = util.CleanRawXml(o.Answer.ModeL3.Ipv6.Address.Text)
+            addrList := o.Answer.ModeL3.Ipv6.Address
+            hasUnrecognized := len(addrList.Other) > 0
+            if len(addrList.Entries) > 0 {
+                list := make([]Ipv6Address, 0, len(addrList.Entries))
+                for _, addr := range addrList.Entries {
+                    if len(addr.Other) > 0 {
+                        hasUnrecognized = true
+                    }
+                    a := Ipv6Address{
+                        Name: addr.Name,
+                        EnableOnInterface: util.AsBool(addr.EnableOnInterface),
+                        Prefix: util.AsBool(addr.Prefix),
+                        Anycast: util.AsBool(addr.Anycast),
+                    }
+                    if addr.Advertise != nil {
+                        a.AdvertiseEnabled = util.AsBool(addr.Advertise.Enable)
+                        a.ValidLifetime = addr.Advertise.ValidLifetime
+                        a.PreferredLifetime = addr.Advertise.PreferredLifetime
+                        a.OnlinkFlag = util.AsBool(addr.Advertise.OnlinkFlag)
+                        a.AutonomousFlag = util.AsBool(addr.Advertise.AutoConfigFlag)
+                    }
+                    list = append(list, a)
+                }
+                ans.Ipv6Addresses = list
+            }
+            if hasUnrecognized && addrList.Raw != "" {
+                ans.raw["ipv6"] = util.CleanRawXml(addrList.Raw)
+            }
+            if nd := o.Answer.ModeL3.Ipv6.NeighborDiscovery; nd != nil {
+                d := &NeighborDiscovery{
+                    DadAttempts: nd.DadAttempts,
+                    NsInterval: nd.NsInterval,
+                    ReachableTime: nd.ReachableTime,
+                }
+                if ra := nd.RouterAdvertisement; ra != nil {
+                    d.RaEnabled = util.AsBool(ra.Enable)
+                    d.RaMaxInterval = ra.MaxInterval
+                    d.RaMinInterval = ra.MinInterval
+                    d.RaManagedFlag = util.AsBool(ra.ManagedFlag)
+                    d.RaOtherFlag = util.AsBool(ra.OtherFlag)
+                    d.RaLifetime = ra.Lifetime
+                    d.RaHopLimit = ra.HopLimit
+                    d.RaLinkMtu = ra.LinkMtu
+                    d.RaRouterPreference = ra.RouterPreference
+                    if ra.DnsSupport != nil {
+                        d.RaDnsSupportEnabled = util.AsBool(ra.DnsSupport.Enable)
+                        for _, s := range ra.DnsSupport.Server {
+                            d.RdnssServers = append(d.RdnssServers, RdnssServer{Server: s.Name, Lifetime: s.Lifetime})
+                        }
+                        d.DnsSearchList = util.EntToStr(ra.DnsSupport.Suffix)
+                    }
+                }
+                ans.NeighborDiscovery = d
+            }
+            if dhcp := o.Answer.ModeL3.Ipv6.Dhcp; dhcp != nil {
+                ans.Dhcpv6Client = &Dhcpv6Client{
+                    Enable: util.AsBool(dhcp.Enable),
+                    PrefixDelegationEnabled: util.AsBool(dhcp.PrefixDelegationEnable),
+                    RapidCommit: util.AsBool(dhcp.RapidCommit),
+                }
             }
         case o.Answer.ModeL2 != nil:
             ans.Mode = "layer2"
             ans.LldpEnabled = util.AsBool(o.Answer.ModeL2.LldpEnabled)
             ans.LldpProfile = o.Answer.ModeL2.LldpProfile
+            ans.LldpHighAvailability = util.AsBool(o.Answer.ModeL2.LldpHighAvailability)
+            ans.LldpPassivePreNegotiation = util.AsBool(o.Answer.ModeL2.LldpPassivePreNegotiation)
             ans.NetflowProfile = o.Answer.ModeL2.NetflowProfile
             if o.Answer.ModeL2.Subinterface != nil {
                 ans.raw["l2subinterface"] = util.CleanRawXml(o.Answer.ModeL2.Subinterface.Text)
@@ -377,6 +598,8 @@ func (o *container_v2) Normalize() Entry {
             ans.Mode = "virtual-wire"
             ans.LldpEnabled = util.AsBool(o.Answer.ModeVwire.LldpEnabled)
             ans.LldpProfile = o.Answer.ModeVwire.LldpProfile
+            ans.LldpHighAvailability = util.AsBool(o.Answer.ModeVwire.LldpHighAvailability)
+            ans.LldpPassivePreNegotiation = util.AsBool(o.Answer.ModeVwire.LldpPassivePreNegotiation)
             ans.NetflowProfile = o.Answer.ModeVwire.NetflowProfile
         case o.Answer.TapMode != nil:
             ans.Mode = "tap"
@@ -386,6 +609,18 @@ func (o *container_v2) Normalize() Entry {
             ans.Mode = "decrypt-mirror"
         case o.Answer.AggregateGroupMode != nil:
             ans.Mode = "aggregate-group"
+            if o.Answer.AggregateGroupMode.Lacp != nil {
+                l := o.Answer.AggregateGroupMode.Lacp
+                ans.Lacp = &Lacp{
+                    Enable: util.AsBool(l.Enable),
+                    Mode: l.Mode,
+                    TransmissionRate: l.TransmissionRate,
+                    SystemPriority: l.SystemPriority,
+                    MaxPorts: l.MaxPorts,
+                    FastFailover: util.AsBool(l.FastFailover),
+                    PortPriority: l.PortPriority,
+                }
+            }
     }
 
     return ans
@@ -400,7 +635,7 @@ type entry_v2 struct {
     TapMode *emptyMode `xml:"tap"`
     HaMode *emptyMode `xml:"ha"`
     DecryptMirrorMode *emptyMode `xml:"decrypt-mirror"`
-    AggregateGroupMode *emptyMode `xml:"aggregate-group"`
+    AggregateGroupMode *aggMode `xml:"aggregate-group"`
     LinkSpeed string `xml:"link-speed,omitempty"`
     LinkDuplex string `xml:"link-duplex,omitempty"`
     LinkState string `xml:"link-state,omitempty"`
@@ -408,7 +643,7 @@ type entry_v2 struct {
 }
 
 type l3Mode_v2 struct {
-    Ipv6 ipv6 `xml:"ipv6"`
+    Ipv6 ipv6_v2 `xml:"ipv6"`
     ManagementProfile string `xml:"interface-management-profile,omitempty"`
     Mtu int `xml:"mtu,omitempty"`
     NetflowProfile string `xml:"netflow-profile,omitempty"`
@@ -421,6 +656,100 @@ type l3Mode_v2 struct {
     Subinterface *util.RawXml `xml:"units"`
 }
 
+// ipv6_v2 is the 7.1+ ipv6 config block, supporting typed addresses,
+// neighbor discovery, and a DHCPv6 client in addition to the interface-id.
+// The Address element keeps its raw inner XML alongside the typed entries,
+// and Normalize only surfaces that raw fallback when it actually holds more
+// than the typed entries do (an unrecognized sibling element, or an
+// unrecognized child inside a recognized <entry>), so that elements this
+// package does not yet recognize survive a read/write round trip instead of
+// being dropped.
+type ipv6_v2 struct {
+    Enabled string `xml:"enabled"`
+    InterfaceId string `xml:"interface-id,omitempty"`
+    Address ipv6AddressList `xml:"address"`
+    NeighborDiscovery *neighborDiscovery `xml:"neighbor-discovery"`
+    Dhcp *ipv6DhcpClient `xml:"dhcp-client"`
+}
+
+type ipv6AddressList struct {
+    Entries []ipv6AddrEntry `xml:"entry"`
+
+    // Other catches any child element of <address> besides <entry> (e.g. a
+    // future PAN-OS sibling this package doesn't model), so Normalize can
+    // tell whether Raw holds more than what Entries already captures.
+    Other []rawChildElement `xml:",any"`
+    Raw string `xml:",innerxml"`
+}
+
+type ipv6AddrEntry struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    EnableOnInterface string `xml:"enable-on-interface"`
+    Prefix string `xml:"prefix"`
+    Anycast string `xml:"anycast"`
+    Advertise *ipv6Advertise `xml:"advertise"`
+
+    // Other catches any child element of this <entry> this package doesn't
+    // model yet, so Normalize can tell the entry isn't fully represented by
+    // the typed fields above.
+    Other []rawChildElement `xml:",any"`
+}
+
+// rawChildElement captures one XML child element by name and inner content,
+// for use as an ",any" catch-all field that detects unrecognized elements
+// without needing to know their shape in advance.
+type rawChildElement struct {
+    XMLName xml.Name
+    Inner string `xml:",innerxml"`
+}
+
+type ipv6Advertise struct {
+    Enable string `xml:"enable"`
+    ValidLifetime int `xml:"valid-lifetime,omitempty"`
+    PreferredLifetime int `xml:"preferred-lifetime,omitempty"`
+    OnlinkFlag string `xml:"onlink-flag"`
+    AutoConfigFlag string `xml:"auto-config-flag"`
+}
+
+type neighborDiscovery struct {
+    DadAttempts int `xml:"dad-attempts,omitempty"`
+    NsInterval int `xml:"ns-interval,omitempty"`
+    ReachableTime int `xml:"reachable-time,omitempty"`
+    RouterAdvertisement *routerAdvertisement `xml:"router-advertisement"`
+}
+
+type routerAdvertisement struct {
+    Enable string `xml:"enable"`
+    MaxInterval int `xml:"max-interval,omitempty"`
+    MinInterval int `xml:"min-interval,omitempty"`
+    ManagedFlag string `xml:"managed-flag"`
+    OtherFlag string `xml:"other-flag"`
+    Lifetime int `xml:"lifetime,omitempty"`
+    HopLimit int `xml:"hop-limit,omitempty"`
+    LinkMtu string `xml:"link-mtu,omitempty"`
+    RouterPreference string `xml:"router-preference,omitempty"`
+    DnsSupport *dnsSupport `xml:"dns-support"`
+}
+
+type dnsSupport struct {
+    Enable string `xml:"enable"`
+    Server []rdnssServer `xml:"server>entry"`
+    Suffix *util.Entry `xml:"suffix"`
+}
+
+type rdnssServer struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    Lifetime int `xml:"lifetime,omitempty"`
+}
+
+type ipv6DhcpClient struct {
+    Enable string `xml:"enable"`
+    PrefixDelegationEnable string `xml:"prefix-delegation>enable"`
+    RapidCommit string `xml:"v6-options>rapid-commit"`
+}
+
 func specify_v1(e Entry) interface{} {
     ans := entry_v1{
         Name: e.Name,
@@ -461,6 +790,8 @@ func specify_v1(e Entry) interface{} {
         i := &otherMode{
             LldpEnabled: util.YesNo(e.LldpEnabled),
             LldpProfile: e.LldpProfile,
+            LldpHighAvailability: util.YesNo(e.LldpHighAvailability),
+            LldpPassivePreNegotiation: util.YesNo(e.LldpPassivePreNegotiation),
             NetflowProfile: e.NetflowProfile,
         }
         if text, present := e.raw["l2subinterface"]; present {
@@ -471,6 +802,8 @@ func specify_v1(e Entry) interface{} {
         i := &otherMode{
             LldpEnabled: util.YesNo(e.LldpEnabled),
             LldpProfile: e.LldpProfile,
+            LldpHighAvailability: util.YesNo(e.LldpHighAvailability),
+            LldpPassivePreNegotiation: util.YesNo(e.LldpPassivePreNegotiation),
             NetflowProfile: e.NetflowProfile,
         }
         ans.ModeVwire = i
@@ -481,7 +814,18 @@ func specify_v1(e Entry) interface{} {
     case "decrypt-mirror":
         ans.DecryptMirrorMode = &emptyMode{}
     case "aggregate-group":
-        ans.AggregateGroupMode = &emptyMode{}
+        ans.AggregateGroupMode = &aggMode{}
+        if e.Lacp != nil {
+            ans.AggregateGroupMode.Lacp = &lacp{
+                Enable: util.YesNo(e.Lacp.Enable),
+                Mode: e.Lacp.Mode,
+                TransmissionRate: e.Lacp.TransmissionRate,
+                SystemPriority: e.Lacp.SystemPriority,
+                MaxPorts: e.Lacp.MaxPorts,
+                FastFailover: util.YesNo(e.Lacp.FastFailover),
+                PortPriority: e.Lacp.PortPriority,
+            }
+        }
     }
 
     return ans
@@ -508,6 +852,7 @@ func specify_v2(e Entry) interface{} {
             Ipv6MssAdjust: e.Ipv6MssAdjust,
         }
         i.Ipv6.Enabled = util.YesNo(e.Ipv6Enabled)
+        i.Ipv6.InterfaceId = e.Ipv6InterfaceId
         if e.EnableDhcp || e.CreateDhcpDefaultRoute || e.DhcpDefaultRouteMetric != 0 {
             i.Dhcp = &dhcpSettings{
                 Enable: util.YesNo(e.EnableDhcp),
@@ -521,14 +866,77 @@ func specify_v2(e Entry) interface{} {
         if text, present := e.raw["l3subinterface"]; present {
             i.Subinterface = &util.RawXml{text}
         }
+        // The raw fallback, when present, means PAN-OS returned an address
+        // list with something this package doesn't model (see
+        // ipv6AddressList.Other); prefer it over the typed entries so that
+        // content survives even though it means edits to Ipv6Addresses
+        // won't take effect until that content is modeled and the raw
+        // fallback stops being populated for this interface.
         if text, present := e.raw["ipv6"]; present {
-            i.Ipv6.Address = &util.RawXml{text}
+            i.Ipv6.Address = ipv6AddressList{Raw: text}
+        } else if len(e.Ipv6Addresses) > 0 {
+            entries := make([]ipv6AddrEntry, 0, len(e.Ipv6Addresses))
+            for _, addr := range e.Ipv6Addresses {
+                entries = append(entries, ipv6AddrEntry{
+                    Name: addr.Name,
+                    EnableOnInterface: util.YesNo(addr.EnableOnInterface),
+                    Prefix: util.YesNo(addr.Prefix),
+                    Anycast: util.YesNo(addr.Anycast),
+                    Advertise: &ipv6Advertise{
+                        Enable: util.YesNo(addr.AdvertiseEnabled),
+                        ValidLifetime: addr.ValidLifetime,
+                        PreferredLifetime: addr.PreferredLifetime,
+                        OnlinkFlag: util.YesNo(addr.OnlinkFlag),
+                        AutoConfigFlag: util.YesNo(addr.AutonomousFlag),
+                    },
+                })
+            }
+            i.Ipv6.Address = ipv6AddressList{Entries: entries}
+        }
+        if nd := e.NeighborDiscovery; nd != nil {
+            d := &neighborDiscovery{
+                DadAttempts: nd.DadAttempts,
+                NsInterval: nd.NsInterval,
+                ReachableTime: nd.ReachableTime,
+                RouterAdvertisement: &routerAdvertisement{
+                    Enable: util.YesNo(nd.RaEnabled),
+                    MaxInterval: nd.RaMaxInterval,
+                    MinInterval: nd.RaMinInterval,
+                    ManagedFlag: util.YesNo(nd.RaManagedFlag),
+                    OtherFlag: util.YesNo(nd.RaOtherFlag),
+                    Lifetime: nd.RaLifetime,
+                    HopLimit: nd.RaHopLimit,
+                    LinkMtu: nd.RaLinkMtu,
+                    RouterPreference: nd.RaRouterPreference,
+                },
+            }
+            if len(nd.RdnssServers) > 0 || len(nd.DnsSearchList) > 0 || nd.RaDnsSupportEnabled {
+                servers := make([]rdnssServer, 0, len(nd.RdnssServers))
+                for _, s := range nd.RdnssServers {
+                    servers = append(servers, rdnssServer{Name: s.Server, Lifetime: s.Lifetime})
+                }
+                d.RouterAdvertisement.DnsSupport = &dnsSupport{
+                    Enable: util.YesNo(nd.RaDnsSupportEnabled),
+                    Server: servers,
+                    Suffix: util.StrToEnt(nd.DnsSearchList),
+                }
+            }
+            i.Ipv6.NeighborDiscovery = d
+        }
+        if dhcp := e.Dhcpv6Client; dhcp != nil {
+            i.Ipv6.Dhcp = &ipv6DhcpClient{
+                Enable: util.YesNo(dhcp.Enable),
+                PrefixDelegationEnable: util.YesNo(dhcp.PrefixDelegationEnabled),
+                RapidCommit: util.YesNo(dhcp.RapidCommit),
+            }
         }
         ans.ModeL3 = i
     case "layer2":
         i := &otherMode{
             LldpEnabled: util.YesNo(e.LldpEnabled),
             LldpProfile: e.LldpProfile,
+            LldpHighAvailability: util.YesNo(e.LldpHighAvailability),
+            LldpPassivePreNegotiation: util.YesNo(e.LldpPassivePreNegotiation),
             NetflowProfile: e.NetflowProfile,
         }
         if text, present := e.raw["l2subinterface"]; present {
@@ -539,6 +947,8 @@ func specify_v2(e Entry) interface{} {
         i := &otherMode{
             LldpEnabled: util.YesNo(e.LldpEnabled),
             LldpProfile: e.LldpProfile,
+            LldpHighAvailability: util.YesNo(e.LldpHighAvailability),
+            LldpPassivePreNegotiation: util.YesNo(e.LldpPassivePreNegotiation),
             NetflowProfile: e.NetflowProfile,
         }
         ans.ModeVwire = i
@@ -549,7 +959,18 @@ func specify_v2(e Entry) interface{} {
     case "decrypt-mirror":
         ans.DecryptMirrorMode = &emptyMode{}
     case "aggregate-group":
-        ans.AggregateGroupMode = &emptyMode{}
+        ans.AggregateGroupMode = &aggMode{}
+        if e.Lacp != nil {
+            ans.AggregateGroupMode.Lacp = &lacp{
+                Enable: util.YesNo(e.Lacp.Enable),
+                Mode: e.Lacp.Mode,
+                TransmissionRate: e.Lacp.TransmissionRate,
+                SystemPriority: e.Lacp.SystemPriority,
+                MaxPorts: e.Lacp.MaxPorts,
+                FastFailover: util.YesNo(e.Lacp.FastFailover),
+                PortPriority: e.Lacp.PortPriority,
+            }
+        }
     }
 
     return ans
@@ -0,0 +1,194 @@
+// Package layer2subinterface is the
+// client.Network.EthernetInterface.Layer2Subinterface namespace.
+//
+// Normalized object:  Entry
+package layer2subinterface
+
+import (
+    "fmt"
+    "encoding/xml"
+
+    "github.com/PaloAltoNetworks/xapi/util"
+)
+
+// Entry is a normalized, version independent representation of a layer2
+// ethernet subinterface (a tagged VLAN unit of an aggregate or physical
+// ethernet interface in layer2 mode).
+type Entry struct {
+    Name string
+    Tag int
+    NetflowProfile string
+    Comment string
+}
+
+// Subinterface is the client.Network.EthernetInterface.Layer2Subinterface
+// namespace.
+//
+// This namespace is parented by a specific ethernet interface (e.g.
+// "ethernet1/1" or "ae1"); construct it with New(), giving it the parent
+// interface's name.
+type Subinterface struct {
+    con util.XapiClient
+    eth string
+}
+
+// New returns a Subinterface namespace for the units of the given parent
+// ethernet interface.
+func New(con util.XapiClient, eth string) *Subinterface {
+    return &Subinterface{con: con, eth: eth}
+}
+
+// ShowList performs SHOW to retrieve a list of layer2 subinterfaces.
+func (c *Subinterface) ShowList() ([]string, error) {
+    c.con.LogQuery("(show) list of layer2 subinterfaces for %q", c.eth)
+    path := c.xpath(nil)
+    return c.con.EntryListUsing(c.con.Show, path[:len(path) - 1])
+}
+
+// GetList performs GET to retrieve a list of layer2 subinterfaces.
+func (c *Subinterface) GetList() ([]string, error) {
+    c.con.LogQuery("(get) list of layer2 subinterfaces for %q", c.eth)
+    path := c.xpath(nil)
+    return c.con.EntryListUsing(c.con.Get, path[:len(path) - 1])
+}
+
+// Get performs GET to retrieve information for the given layer2 subinterface.
+func (c *Subinterface) Get(name string) (Entry, error) {
+    c.con.LogQuery("(get) layer2 subinterface %q", name)
+    return c.details(c.con.Get, name)
+}
+
+// Show performs SHOW to retrieve information for the given layer2 subinterface.
+func (c *Subinterface) Show(name string) (Entry, error) {
+    c.con.LogQuery("(show) layer2 subinterface %q", name)
+    return c.details(c.con.Show, name)
+}
+
+// Set creates / updates one or more layer2 subinterfaces.
+func (c *Subinterface) Set(e ...Entry) error {
+    var err error
+
+    if len(e) == 0 {
+        return nil
+    }
+
+    names := make([]string, len(e))
+
+    // Build up the struct with the given subinterface configs.
+    d := util.BulkElement{XMLName: xml.Name{Local: "units"}}
+    for i := range e {
+        d.Data = append(d.Data, specify(e[i]))
+        names[i] = e[i].Name
+    }
+    c.con.LogAction("(set) layer2 subinterfaces: %v", names)
+
+    // Set xpath.
+    path := c.xpath(names)
+    if len(e) == 1 {
+        path = path[:len(path) - 1]
+    } else {
+        path = path[:len(path) - 2]
+    }
+
+    _, err = c.con.Set(path, d.Config(), nil, nil)
+    return err
+}
+
+// Edit creates / updates the specified layer2 subinterface.
+func (c *Subinterface) Edit(e Entry) error {
+    var err error
+
+    c.con.LogAction("(edit) layer2 subinterface: %v", e.Name)
+
+    path := c.xpath([]string{e.Name})
+    _, err = c.con.Edit(path, specify(e), nil, nil)
+    return err
+}
+
+// Delete removes the given subinterface(s) from the firewall.
+//
+// Subinterfaces can be a string or an Entry object.
+func (c *Subinterface) Delete(e ...interface{}) error {
+    var err error
+
+    if len(e) == 0 {
+        return nil
+    }
+
+    names := make([]string, len(e))
+    for i := range e {
+        switch v := e[i].(type) {
+        case string:
+            names[i] = v
+        case Entry:
+            names[i] = v.Name
+        default:
+            return fmt.Errorf("Unknown type sent to delete: %s", v)
+        }
+    }
+    c.con.LogAction("(delete) layer2 subinterface(s): %v", names)
+
+    path := c.xpath(names)
+    _, err = c.con.Delete(path, nil, nil)
+    return err
+}
+
+/** Internal functions for the Subinterface struct **/
+
+func (c *Subinterface) details(fn func(interface{}, interface{}, interface{}) ([]byte, error), name string) (Entry, error) {
+    path := c.xpath([]string{name})
+    obj := &container{}
+    if _, err := fn(path, nil, obj); err != nil {
+        return Entry{}, err
+    }
+    ans := obj.Normalize()
+
+    return ans, nil
+}
+
+func (c *Subinterface) xpath(vals []string) []string {
+    return []string{
+        "config",
+        "devices",
+        util.AsEntryXpath([]string{"localhost.localdomain"}),
+        "network",
+        "interface",
+        "ethernet",
+        util.AsEntryXpath([]string{c.eth}),
+        "layer2",
+        "units",
+        util.AsEntryXpath(vals),
+    }
+}
+
+/** Structs / functions for this namespace. **/
+
+type container struct {
+    Answer entry `xml:"result>entry"`
+}
+
+func (o *container) Normalize() Entry {
+    return Entry{
+        Name: o.Answer.Name,
+        Tag: o.Answer.Tag,
+        NetflowProfile: o.Answer.NetflowProfile,
+        Comment: o.Answer.Comment,
+    }
+}
+
+type entry struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    Tag int `xml:"tag,omitempty"`
+    NetflowProfile string `xml:"netflow-profile,omitempty"`
+    Comment string `xml:"comment"`
+}
+
+func specify(e Entry) interface{} {
+    return entry{
+        Name: e.Name,
+        Tag: e.Tag,
+        NetflowProfile: e.NetflowProfile,
+        Comment: e.Comment,
+    }
+}
@@ -0,0 +1,95 @@
+package eth
+
+import (
+    "encoding/xml"
+    "fmt"
+
+    "github.com/PaloAltoNetworks/xapi/version"
+)
+
+// Driver normalizes and specifies ethernet interface config for a single
+// PAN-OS schema generation.
+//
+// Third parties that need to support a PAN-OS release with interface config
+// fields this package doesn't yet know about can implement Driver and
+// register it with RegisterVersion instead of waiting on a patch here.
+type Driver interface {
+    // Normalize turns the raw API response body for a single entry into an
+    // Entry.
+    Normalize(raw []byte) (Entry, error)
+
+    // Specify turns an Entry into the value the xapi client should marshal
+    // into the request body.
+    Specify(e Entry) (interface{}, error)
+}
+
+type versionedDriver struct {
+    min version.Number
+    factory func() Driver
+}
+
+var driverRegistry []versionedDriver
+
+// RegisterVersion registers a Driver factory for the given minimum PAN-OS
+// version. When multiple registered drivers apply to a given PAN-OS
+// version, the one with the highest minimum version wins.
+func RegisterVersion(minVer version.Number, factory func() Driver) {
+    driverRegistry = append(driverRegistry, versionedDriver{min: minVer, factory: factory})
+}
+
+func init() {
+    RegisterVersion(version.Number{0, 0, 0, ""}, func() Driver { return &driver_v1{} })
+    RegisterVersion(version.Number{7, 1, 0, ""}, func() Driver { return &driver_v2{} })
+}
+
+// bestDriver returns the highest-registered Driver whose minimum version is
+// less than or equal to v.
+func bestDriver(v version.Number) Driver {
+    var best *versionedDriver
+
+    for i := range driverRegistry {
+        r := &driverRegistry[i]
+        if !v.Gte(r.min) {
+            continue
+        }
+        if best == nil || r.min.Gte(best.min) {
+            best = r
+        }
+    }
+
+    if best == nil {
+        panic(fmt.Sprintf("eth: no driver registered that supports PAN-OS %v", v))
+    }
+
+    return best.factory()
+}
+
+type driver_v1 struct{}
+
+func (d *driver_v1) Normalize(raw []byte) (Entry, error) {
+    ans := container_v1{}
+    if err := xml.Unmarshal(raw, &ans); err != nil {
+        return Entry{}, err
+    }
+
+    return ans.Normalize(), nil
+}
+
+func (d *driver_v1) Specify(e Entry) (interface{}, error) {
+    return specify_v1(e), nil
+}
+
+type driver_v2 struct{}
+
+func (d *driver_v2) Normalize(raw []byte) (Entry, error) {
+    ans := container_v2{}
+    if err := xml.Unmarshal(raw, &ans); err != nil {
+        return Entry{}, err
+    }
+
+    return ans.Normalize(), nil
+}
+
+func (d *driver_v2) Specify(e Entry) (interface{}, error) {
+    return specify_v2(e), nil
+}
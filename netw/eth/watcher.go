@@ -0,0 +1,304 @@
+package eth
+
+import (
+    "reflect"
+    "sync"
+    "time"
+)
+
+// EventKind describes what changed about an interface between two polls.
+type EventKind int
+
+const (
+    Added EventKind = iota
+    Removed
+    Modified
+)
+
+// Event describes a single interface change detected by a Watcher.
+type Event struct {
+    Name string
+    Kind EventKind
+    Old Entry
+    New Entry
+    ChangedFields []string
+}
+
+// WatcherOptions configures a Watcher.
+type WatcherOptions struct {
+    // PollInterval is how often GetList() / Get() are polled. Defaults to
+    // 30 seconds if unset.
+    PollInterval time.Duration
+
+    // NameFilter, if given, restricts polling to interfaces for which it
+    // returns true. A nil NameFilter watches every interface returned by
+    // GetList().
+    NameFilter func(name string) bool
+
+    // VsysFilter, if given, restricts polling to interfaces for which it
+    // returns true. Eth's own GetList()/Get() aren't scoped to a vsys, so
+    // this is meant to be backed by a vsys membership lookup (e.g. the
+    // vsys's imported interface list) rather than anything this package
+    // queries itself. A nil VsysFilter watches every interface regardless
+    // of vsys membership.
+    VsysFilter func(name string) bool
+
+    // LinkState, if given, is consulted for each polled interface and its
+    // result overlaid onto Entry.LinkState before diffing. This is meant to
+    // be backed by a PAN-OS "show interface" op-command lookup so that
+    // admin-config polling and live link state share one event stream.
+    LinkState func(name string) (string, error)
+}
+
+// Watcher polls an Eth namespace for interface changes and publishes them to
+// any number of subscribers.
+//
+// Use NewWatcher to create one; call Close when done with it to stop the
+// poll loop.
+type Watcher struct {
+    eth *Eth
+    opts WatcherOptions
+
+    mu sync.Mutex
+    subs map[int]chan Event
+    nextId int
+    snapshot map[string]Entry
+
+    cancel chan struct{}
+    closeOnce sync.Once
+}
+
+// NewWatcher creates a Watcher over the given Eth namespace and starts its
+// poll loop in the background.
+func NewWatcher(c *Eth, opts WatcherOptions) *Watcher {
+    if opts.PollInterval <= 0 {
+        opts.PollInterval = 30 * time.Second
+    }
+
+    w := &Watcher{
+        eth: c,
+        opts: opts,
+        subs: make(map[int]chan Event),
+        snapshot: make(map[string]Entry),
+        cancel: make(chan struct{}),
+    }
+
+    go w.loop()
+
+    return w
+}
+
+// Subscribe returns a channel of Events and a cancel function to stop
+// receiving them. Safe to call from any number of goroutines concurrently.
+func (w *Watcher) Subscribe() (<-chan Event, func()) {
+    w.mu.Lock()
+    id := w.nextId
+    w.nextId++
+    ch := make(chan Event, 16)
+    w.subs[id] = ch
+    w.mu.Unlock()
+
+    cancel := func() {
+        w.mu.Lock()
+        defer w.mu.Unlock()
+        if c, ok := w.subs[id]; ok {
+            delete(w.subs, id)
+            close(c)
+        }
+    }
+
+    return ch, cancel
+}
+
+// Close stops the poll loop. It does not close subscriber channels; cancel
+// each subscription via the func returned from Subscribe.
+func (w *Watcher) Close() {
+    w.closeOnce.Do(func() {
+        close(w.cancel)
+    })
+}
+
+func (w *Watcher) loop() {
+    ticker := time.NewTicker(w.opts.PollInterval)
+    defer ticker.Stop()
+
+    w.poll()
+
+    for {
+        select {
+        case <-w.cancel:
+            return
+        case <-ticker.C:
+            w.poll()
+        }
+    }
+}
+
+func (w *Watcher) poll() {
+    names, err := w.eth.GetList()
+    if err != nil {
+        return
+    }
+
+    current := make(map[string]Entry, len(names))
+    for _, name := range names {
+        if w.opts.NameFilter != nil && !w.opts.NameFilter(name) {
+            continue
+        }
+        if w.opts.VsysFilter != nil && !w.opts.VsysFilter(name) {
+            continue
+        }
+
+        entry, err := w.eth.Get(name)
+        if err != nil {
+            continue
+        }
+
+        if w.opts.LinkState != nil {
+            if ls, err := w.opts.LinkState(name); err == nil {
+                entry.LinkState = ls
+            }
+        }
+
+        current[name] = entry
+    }
+
+    w.mu.Lock()
+    prev := w.snapshot
+    w.snapshot = current
+    w.mu.Unlock()
+
+    for name, entry := range current {
+        old, existed := prev[name]
+        if !existed {
+            w.publish(Event{Name: name, Kind: Added, New: entry})
+            continue
+        }
+        if !reflect.DeepEqual(old, entry) {
+            w.publish(Event{Name: name, Kind: Modified, Old: old, New: entry, ChangedFields: changedFields(old, entry)})
+        }
+    }
+
+    for name, entry := range prev {
+        if _, exists := current[name]; !exists {
+            w.publish(Event{Name: name, Kind: Removed, Old: entry})
+        }
+    }
+}
+
+func (w *Watcher) publish(e Event) {
+    w.mu.Lock()
+    defer w.mu.Unlock()
+
+    for _, ch := range w.subs {
+        select {
+        case ch <- e:
+        default:
+            // Subscriber isn't keeping up; drop the event rather than stall
+            // the poll loop.
+        }
+    }
+}
+
+// changedFields reports which fields differ between two observations of the
+// same interface, for callers that want a quick summary of what changed
+// without diffing the full Entry themselves. The Modified event itself is
+// gated on a full reflect.DeepEqual of old vs. updated, so this list is
+// purely an annotation: a Modified event can still fire over a field this
+// function doesn't enumerate.
+func changedFields(old, updated Entry) []string {
+    var out []string
+
+    if old.LinkState != updated.LinkState {
+        out = append(out, "LinkState")
+    }
+    if old.LinkSpeed != updated.LinkSpeed {
+        out = append(out, "LinkSpeed")
+    }
+    if old.LinkDuplex != updated.LinkDuplex {
+        out = append(out, "LinkDuplex")
+    }
+    if old.Mode != updated.Mode {
+        out = append(out, "Mode")
+    }
+    if old.EnableDhcp != updated.EnableDhcp {
+        out = append(out, "EnableDhcp")
+    }
+    if old.CreateDhcpDefaultRoute != updated.CreateDhcpDefaultRoute {
+        out = append(out, "CreateDhcpDefaultRoute")
+    }
+    if old.DhcpDefaultRouteMetric != updated.DhcpDefaultRouteMetric {
+        out = append(out, "DhcpDefaultRouteMetric")
+    }
+    if !stringSlicesEqual(old.StaticIps, updated.StaticIps) {
+        out = append(out, "StaticIps")
+    }
+    if old.Ipv6Enabled != updated.Ipv6Enabled {
+        out = append(out, "Ipv6Enabled")
+    }
+    if old.Ipv6InterfaceId != updated.Ipv6InterfaceId {
+        out = append(out, "Ipv6InterfaceId")
+    }
+    if !reflect.DeepEqual(old.Ipv6Addresses, updated.Ipv6Addresses) {
+        out = append(out, "Ipv6Addresses")
+    }
+    if !reflect.DeepEqual(old.NeighborDiscovery, updated.NeighborDiscovery) {
+        out = append(out, "NeighborDiscovery")
+    }
+    if !reflect.DeepEqual(old.Dhcpv6Client, updated.Dhcpv6Client) {
+        out = append(out, "Dhcpv6Client")
+    }
+    if old.ManagementProfile != updated.ManagementProfile {
+        out = append(out, "ManagementProfile")
+    }
+    if old.Mtu != updated.Mtu {
+        out = append(out, "Mtu")
+    }
+    if old.AdjustTcpMss != updated.AdjustTcpMss {
+        out = append(out, "AdjustTcpMss")
+    }
+    if old.Ipv4MssAdjust != updated.Ipv4MssAdjust {
+        out = append(out, "Ipv4MssAdjust")
+    }
+    if old.Ipv6MssAdjust != updated.Ipv6MssAdjust {
+        out = append(out, "Ipv6MssAdjust")
+    }
+    if old.NetflowProfile != updated.NetflowProfile {
+        out = append(out, "NetflowProfile")
+    }
+    if old.LldpEnabled != updated.LldpEnabled {
+        out = append(out, "LldpEnabled")
+    }
+    if old.LldpProfile != updated.LldpProfile {
+        out = append(out, "LldpProfile")
+    }
+    if old.LldpHighAvailability != updated.LldpHighAvailability {
+        out = append(out, "LldpHighAvailability")
+    }
+    if old.LldpPassivePreNegotiation != updated.LldpPassivePreNegotiation {
+        out = append(out, "LldpPassivePreNegotiation")
+    }
+    if old.AggregateGroup != updated.AggregateGroup {
+        out = append(out, "AggregateGroup")
+    }
+    if !reflect.DeepEqual(old.Lacp, updated.Lacp) {
+        out = append(out, "Lacp")
+    }
+    if old.Comment != updated.Comment {
+        out = append(out, "Comment")
+    }
+
+    return out
+}
+
+func stringSlicesEqual(a, b []string) bool {
+    if len(a) != len(b) {
+        return false
+    }
+    for i := range a {
+        if a[i] != b[i] {
+            return false
+        }
+    }
+    return true
+}
@@ -0,0 +1,119 @@
+package eth
+
+import (
+    "reflect"
+    "sort"
+    "testing"
+)
+
+func TestChangedFields(t *testing.T) {
+    testCases := []struct {
+        name string
+        old Entry
+        updated Entry
+        want []string
+    }{
+        {
+            name: "no difference",
+            old: Entry{Name: "ethernet1/1", LinkState: "up"},
+            updated: Entry{Name: "ethernet1/1", LinkState: "up"},
+            want: nil,
+        },
+        {
+            name: "link state change",
+            old: Entry{LinkState: "down"},
+            updated: Entry{LinkState: "up"},
+            want: []string{"LinkState"},
+        },
+        {
+            name: "lacp change is reported",
+            old: Entry{Lacp: &Lacp{Enable: false}},
+            updated: Entry{Lacp: &Lacp{Enable: true, Mode: "active"}},
+            want: []string{"Lacp"},
+        },
+        {
+            name: "neighbor discovery change is reported",
+            old: Entry{NeighborDiscovery: nil},
+            updated: Entry{NeighborDiscovery: &NeighborDiscovery{RaEnabled: true}},
+            want: []string{"NeighborDiscovery"},
+        },
+        {
+            name: "ipv6 address list change is reported",
+            old: Entry{Ipv6Addresses: []Ipv6Address{{Name: "2001:db8::1"}}},
+            updated: Entry{Ipv6Addresses: []Ipv6Address{{Name: "2001:db8::2"}}},
+            want: []string{"Ipv6Addresses"},
+        },
+    }
+
+    for _, tc := range testCases {
+        t.Run(tc.name, func(t *testing.T) {
+            got := changedFields(tc.old, tc.updated)
+            sort.Strings(got)
+            want := append([]string{}, tc.want...)
+            sort.Strings(want)
+            if (len(got) != 0 || len(want) != 0) && !reflect.DeepEqual(got, want) {
+                t.Errorf("changedFields() = %v, want %v", got, want)
+            }
+        })
+    }
+}
+
+func TestSubscribePublishFanout(t *testing.T) {
+    w := &Watcher{subs: make(map[int]chan Event)}
+
+    ch1, cancel1 := w.Subscribe()
+    ch2, _ := w.Subscribe()
+
+    w.publish(Event{Name: "ethernet1/1", Kind: Added})
+
+    for _, ch := range []<-chan Event{ch1, ch2} {
+        select {
+        case e := <-ch:
+            if e.Name != "ethernet1/1" || e.Kind != Added {
+                t.Errorf("got event %+v, want Added ethernet1/1", e)
+            }
+        default:
+            t.Fatal("subscriber did not receive the published event")
+        }
+    }
+
+    cancel1()
+    if _, ok := <-ch1; ok {
+        t.Error("ch1 should be closed after its cancel func is called")
+    }
+
+    w.publish(Event{Name: "ethernet1/2", Kind: Removed})
+    select {
+    case e, ok := <-ch2:
+        if !ok {
+            t.Fatal("ch2 closed unexpectedly")
+        }
+        if e.Name != "ethernet1/2" || e.Kind != Removed {
+            t.Errorf("got event %+v, want Removed ethernet1/2", e)
+        }
+    default:
+        t.Fatal("remaining subscriber did not receive the second event")
+    }
+}
+
+func TestStringSlicesEqual(t *testing.T) {
+    testCases := []struct {
+        name string
+        a []string
+        b []string
+        want bool
+    }{
+        {name: "both nil", a: nil, b: nil, want: true},
+        {name: "equal", a: []string{"1.1.1.1/24"}, b: []string{"1.1.1.1/24"}, want: true},
+        {name: "different length", a: []string{"1.1.1.1/24"}, b: nil, want: false},
+        {name: "different order", a: []string{"a", "b"}, b: []string{"b", "a"}, want: false},
+    }
+
+    for _, tc := range testCases {
+        t.Run(tc.name, func(t *testing.T) {
+            if got := stringSlicesEqual(tc.a, tc.b); got != tc.want {
+                t.Errorf("stringSlicesEqual(%v, %v) = %v, want %v", tc.a, tc.b, got, tc.want)
+            }
+        })
+    }
+}